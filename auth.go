@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"encoding/hex"
+
+	mesos "github.com/mesos/go-proto/mesos/v1"
+)
+
+// DriverConfig carries the configuration a MesosSchedulerDriver
+// constructor consumes to build a driver. It is not itself wired to a
+// driver in this package; a concrete driver constructor is expected to
+// take a DriverConfig and, when Credential is set, authenticate with the
+// master via SASL CRAM-MD5 (see CredentialHandler) before subscribing and
+// to hold calls such as LaunchTasks/KillTask until that subscription
+// completes. Constraints, when set, is intended to ride the initial
+// SUBSCRIBE call instead of requiring a follow-up UpdateFramework.
+type DriverConfig struct {
+	Scheduler   Scheduler
+	Framework   *mesos.FrameworkInfo
+	Master      string
+	Credential  *mesos.Credential
+	AuthContext func(context.Context) context.Context
+	Constraints *OfferConstraints
+}
+
+// WithAuthContext returns an option that installs fn on a DriverConfig as
+// the hook a driver constructor should use to derive the context for
+// authentication calls (e.g., SASL exchanges wrapped in TLS, or calls
+// that need to carry an auth token). When unset, a driver is expected to
+// fall back to context.Background().
+func WithAuthContext(fn func(context.Context) context.Context) func(*DriverConfig) {
+	return func(c *DriverConfig) {
+		c.AuthContext = fn
+	}
+}
+
+// CredentialHandler drives the SASL callback chain used to authenticate a
+// framework's Credential against a Mesos master. Implementations
+// correspond to a single SASL mechanism; NewCRAMMD5Handler provides the
+// CRAM-MD5 mechanism required by Mesos.
+type CredentialHandler interface {
+	// Mechanism returns the SASL mechanism name advertised to the master
+	// (e.g., "CRAM-MD5").
+	Mechanism() string
+
+	// Start begins the exchange, returning the initial response (if any)
+	// to send in the AuthenticationStartMessage.
+	Start(ctx context.Context) (data []byte, err error)
+
+	// Step answers a server challenge with the next client response,
+	// analogous to the NameCallback/PasswordCallback pair used by the
+	// Java and C++ implementations.
+	Step(ctx context.Context, challenge []byte) (data []byte, err error)
+}
+
+// NewCRAMMD5Handler returns a CredentialHandler implementing SASL
+// CRAM-MD5 for the given credential, matching the mechanism Mesos
+// masters expect when a framework registers with authentication enabled.
+func NewCRAMMD5Handler(cred *mesos.Credential) CredentialHandler {
+	return &cramMD5Handler{cred: cred}
+}
+
+type cramMD5Handler struct {
+	cred *mesos.Credential
+}
+
+func (h *cramMD5Handler) Mechanism() string { return "CRAM-MD5" }
+
+func (h *cramMD5Handler) Start(ctx context.Context) ([]byte, error) {
+	// CRAM-MD5 is server-first: the client sends no initial response and
+	// waits for the master's challenge.
+	return nil, nil
+}
+
+func (h *cramMD5Handler) Step(ctx context.Context, challenge []byte) ([]byte, error) {
+	return cramMD5Response(h.cred.GetPrincipal(), h.cred.GetSecret(), challenge), nil
+}
+
+// cramMD5Response computes the "principal digest" response to a CRAM-MD5
+// challenge, per RFC 2195: HMAC-MD5(secret, challenge) hex-encoded and
+// space-joined with the principal.
+func cramMD5Response(principal string, secret, challenge []byte) []byte {
+	mac := hmac.New(md5.New, secret)
+	mac.Write(challenge)
+	digest := hex.EncodeToString(mac.Sum(nil))
+	return []byte(principal + " " + digest)
+}
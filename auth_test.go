@@ -0,0 +1,18 @@
+package main
+
+import "testing"
+
+func TestCramMD5Response(t *testing.T) {
+	// RFC 2195 section 2 worked example.
+	const (
+		principal = "tim"
+		secret    = "tanstaaftanstaaf"
+		challenge = "<1896.697170952@postoffice.reston.mci.net>"
+		want      = "tim b913a602c7eda7a495b4e6e7334d3890"
+	)
+
+	got := cramMD5Response(principal, []byte(secret), []byte(challenge))
+	if string(got) != want {
+		t.Fatalf("cramMD5Response() = %q, want %q", got, want)
+	}
+}
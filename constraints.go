@@ -0,0 +1,153 @@
+package main
+
+import "fmt"
+
+// OfferConstraints is a tree of per-role constraints a framework may push
+// to the master so the allocator can filter out offers that can never
+// satisfy the framework, reducing wasted ResourceOffers/DeclineOffer
+// cycles. It is sent via SchedulerDriver.UpdateFramework (or supplied at
+// driver construction so it rides the initial SUBSCRIBE call).
+type OfferConstraints struct {
+	// RoleConstraints maps a role name to the constraints that apply to
+	// offers made under that role.
+	RoleConstraints map[string]RoleConstraints
+}
+
+// RoleConstraints holds the constraint groups for a single role. An offer
+// satisfies the role's constraints if it satisfies at least one group
+// (the groups are OR'd together). Each group is a single
+// AttributeConstraint; a group of several AND'd constraints cannot be
+// expressed.
+type RoleConstraints struct {
+	Groups []AttributeConstraint
+}
+
+// AttributeConstraint selects agent attributes by name and requires them
+// to satisfy Predicate in order for an offer to be considered.
+type AttributeConstraint struct {
+	Selector  string
+	Predicate Predicate
+}
+
+// Predicate describes the condition an attribute selected by an
+// AttributeConstraint must satisfy. Exactly one of Exists, NotExists,
+// Equals, or NotEquals must be set; use Validate to check this before
+// sending a Predicate to the master.
+type Predicate struct {
+	Exists    bool
+	NotExists bool
+	Equals    *string
+	NotEquals *string
+}
+
+// Validate reports an error if p does not set exactly one of
+// Exists, NotExists, Equals, or NotEquals.
+func (p Predicate) Validate() error {
+	set := 0
+	if p.Exists {
+		set++
+	}
+	if p.NotExists {
+		set++
+	}
+	if p.Equals != nil {
+		set++
+	}
+	if p.NotEquals != nil {
+		set++
+	}
+	if set != 1 {
+		return fmt.Errorf("predicate must set exactly one of Exists, NotExists, Equals, NotEquals; got %d", set)
+	}
+	return nil
+}
+
+// Equal reports whether p and other describe the same condition.
+func (p Predicate) Equal(other Predicate) bool {
+	if p.Exists != other.Exists || p.NotExists != other.NotExists {
+		return false
+	}
+	if !stringPtrEqual(p.Equals, other.Equals) {
+		return false
+	}
+	return stringPtrEqual(p.NotEquals, other.NotEquals)
+}
+
+func stringPtrEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// Validate reports an error if a's Predicate is malformed (see
+// Predicate.Validate).
+func (a AttributeConstraint) Validate() error {
+	if err := a.Predicate.Validate(); err != nil {
+		return fmt.Errorf("attribute %q: %w", a.Selector, err)
+	}
+	return nil
+}
+
+// Equal reports whether a and other select the same attribute with the
+// same predicate.
+func (a AttributeConstraint) Equal(other AttributeConstraint) bool {
+	return a.Selector == other.Selector && a.Predicate.Equal(other.Predicate)
+}
+
+// Validate reports an error if any of r's constraint groups is malformed.
+func (r RoleConstraints) Validate() error {
+	for _, g := range r.Groups {
+		if err := g.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Equal reports whether r and other hold the same groups in the same
+// order. Groups are OR'd together, but reordering them changes nothing
+// the master observes, so callers that build groups deterministically
+// can rely on order for a cheap comparison. Equal is for spotting a
+// no-op UpdateFramework call, not for expressing set semantics.
+func (r RoleConstraints) Equal(other RoleConstraints) bool {
+	if len(r.Groups) != len(other.Groups) {
+		return false
+	}
+	for i, g := range r.Groups {
+		if !g.Equal(other.Groups[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate reports an error if any role's constraints are malformed.
+func (c *OfferConstraints) Validate() error {
+	for role, rc := range c.RoleConstraints {
+		if err := rc.Validate(); err != nil {
+			return fmt.Errorf("role %q: %w", role, err)
+		}
+	}
+	return nil
+}
+
+// Equal reports whether c and other describe the same constraints for
+// the same set of roles. It is the comparison SchedulerDriver.UpdateFramework
+// names for diffing an update against what was last sent to the master;
+// no driver in this package performs that diff.
+func (c *OfferConstraints) Equal(other *OfferConstraints) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+	if len(c.RoleConstraints) != len(other.RoleConstraints) {
+		return false
+	}
+	for role, rc := range c.RoleConstraints {
+		otherRC, ok := other.RoleConstraints[role]
+		if !ok || !rc.Equal(otherRC) {
+			return false
+		}
+	}
+	return true
+}
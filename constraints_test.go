@@ -0,0 +1,84 @@
+package main
+
+import "testing"
+
+func strp(s string) *string { return &s }
+
+func TestPredicateValidate(t *testing.T) {
+	cases := []struct {
+		name string
+		p    Predicate
+		ok   bool
+	}{
+		{"exists", Predicate{Exists: true}, true},
+		{"not exists", Predicate{NotExists: true}, true},
+		{"equals", Predicate{Equals: strp("gpu")}, true},
+		{"not equals", Predicate{NotEquals: strp("gpu")}, true},
+		{"none set", Predicate{}, false},
+		{"exists and not exists", Predicate{Exists: true, NotExists: true}, false},
+		{"exists and equals", Predicate{Exists: true, Equals: strp("gpu")}, false},
+	}
+	for _, c := range cases {
+		err := c.p.Validate()
+		if c.ok && err != nil {
+			t.Errorf("%s: Validate() = %v, want nil", c.name, err)
+		}
+		if !c.ok && err == nil {
+			t.Errorf("%s: Validate() = nil, want an error", c.name)
+		}
+	}
+}
+
+func TestStringPtrEqual(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b *string
+		want bool
+	}{
+		{"both nil", nil, nil, true},
+		{"nil vs set", nil, strp("gpu"), false},
+		{"set vs nil", strp("gpu"), nil, false},
+		{"equal values", strp("gpu"), strp("gpu"), true},
+		{"different values", strp("gpu"), strp("cpu"), false},
+	}
+	for _, c := range cases {
+		if got := stringPtrEqual(c.a, c.b); got != c.want {
+			t.Errorf("%s: stringPtrEqual() = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestOfferConstraintsEqual(t *testing.T) {
+	build := func() *OfferConstraints {
+		return &OfferConstraints{
+			RoleConstraints: map[string]RoleConstraints{
+				"role1": {
+					Groups: []AttributeConstraint{
+						{Selector: "rack", Predicate: Predicate{Equals: strp("A")}},
+					},
+				},
+			},
+		}
+	}
+
+	a, b := build(), build()
+	if !a.Equal(b) {
+		t.Fatalf("expected two independently built but identical OfferConstraints to be Equal")
+	}
+
+	b.RoleConstraints["role1"] = RoleConstraints{
+		Groups: []AttributeConstraint{
+			{Selector: "rack", Predicate: Predicate{Equals: strp("B")}},
+		},
+	}
+	if a.Equal(b) {
+		t.Fatalf("expected OfferConstraints with different predicate values to differ")
+	}
+
+	if (*OfferConstraints)(nil).Equal(nil) != true {
+		t.Fatalf("expected two nil OfferConstraints to be Equal")
+	}
+	if a.Equal(nil) {
+		t.Fatalf("expected a non-nil OfferConstraints to differ from nil")
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"sync"
+
+	mesos "github.com/mesos/go-proto/mesos/v1"
+	scheduler "github.com/mesos/go-proto/mesos/v1/scheduler"
+)
+
+// OperationRegistry tracks the last known OperationStatus for
+// resource-provider operations accepted via AcceptOffers, so that
+// ReconcileOperations(nil) can perform implicit reconciliation of every
+// operation that hasn't yet reached a terminal state. OperationRegistry
+// itself only tracks state; wiring it into a driver's event loop
+// (Event.UPDATE_OPERATION_STATUS routing, and retrying
+// AcknowledgeOperationStatus on send failure) is the concrete driver's
+// responsibility and is not implemented in this tree.
+type OperationRegistry struct {
+	mu      sync.Mutex
+	tracked map[string]*trackedOperation
+}
+
+// trackedOperation pairs an OperationStatus with the agent and (if any)
+// resource provider it was accepted against. OperationStatus is a state
+// report and does not reliably carry that identity itself, so it must be
+// captured separately at the point the operation was accepted (i.e., from
+// the offer AcceptOffers was called against) in order to reconcile it
+// later.
+type trackedOperation struct {
+	status             *mesos.OperationStatus
+	agentID            string
+	resourceProviderID string
+}
+
+// NewOperationRegistry returns an empty OperationRegistry.
+func NewOperationRegistry() *OperationRegistry {
+	return &OperationRegistry{tracked: make(map[string]*trackedOperation)}
+}
+
+// Track records status as the last known state of an operation accepted
+// against agentID and (if the operation targets a resource provider's
+// resources) resourceProviderID. Terminal operations are untracked,
+// mirroring Reconciler.Track for tasks.
+func (r *OperationRegistry) Track(status *mesos.OperationStatus, agentID, resourceProviderID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := status.GetOperationId().GetValue()
+	if isTerminalOperation(status.GetState()) {
+		delete(r.tracked, id)
+		return
+	}
+	r.tracked[id] = &trackedOperation{
+		status:             status,
+		agentID:            agentID,
+		resourceProviderID: resourceProviderID,
+	}
+}
+
+// NonTerminal returns the set of reconcile operations describing every
+// operation currently tracked as non-terminal, suitable for passing to
+// ReconcileOperations for explicit reconciliation.
+func (r *OperationRegistry) NonTerminal() []*scheduler.Call_ReconcileOperations_Operation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ops := make([]*scheduler.Call_ReconcileOperations_Operation, 0, len(r.tracked))
+	for _, t := range r.tracked {
+		op := &scheduler.Call_ReconcileOperations_Operation{
+			OperationId: t.status.GetOperationId(),
+			AgentId:     &mesos.AgentID{Value: t.agentID},
+		}
+		if t.resourceProviderID != "" {
+			op.ResourceProviderId = &mesos.ResourceProviderID{Value: t.resourceProviderID}
+		}
+		ops = append(ops, op)
+	}
+	return ops
+}
+
+func isTerminalOperation(state mesos.OperationState) bool {
+	switch state {
+	case mesos.OperationState_OPERATION_FINISHED,
+		mesos.OperationState_OPERATION_FAILED,
+		mesos.OperationState_OPERATION_ERROR,
+		mesos.OperationState_OPERATION_DROPPED,
+		mesos.OperationState_OPERATION_GONE_BY_OPERATOR:
+		return true
+	default:
+		return false
+	}
+}
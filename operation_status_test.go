@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+
+	mesos "github.com/mesos/go-proto/mesos/v1"
+)
+
+func TestOperationRegistryTrack(t *testing.T) {
+	reg := NewOperationRegistry()
+
+	pending := &mesos.OperationStatus{
+		OperationId: &mesos.OperationID{Value: strPtr("op1")},
+		State:       mesos.OperationState_OPERATION_PENDING.Enum(),
+	}
+	reg.Track(pending, "agent1", "rp1")
+	got := reg.NonTerminal()
+	if len(got) != 1 {
+		t.Fatalf("NonTerminal() = %v, want 1 entry after tracking a pending operation", got)
+	}
+	if got[0].GetAgentId().GetValue() != "agent1" {
+		t.Fatalf("AgentId = %q, want %q", got[0].GetAgentId().GetValue(), "agent1")
+	}
+	if got[0].GetResourceProviderId().GetValue() != "rp1" {
+		t.Fatalf("ResourceProviderId = %q, want %q", got[0].GetResourceProviderId().GetValue(), "rp1")
+	}
+
+	finished := &mesos.OperationStatus{
+		OperationId: &mesos.OperationID{Value: strPtr("op1")},
+		State:       mesos.OperationState_OPERATION_FINISHED.Enum(),
+	}
+	reg.Track(finished, "agent1", "rp1")
+	if got := reg.NonTerminal(); len(got) != 0 {
+		t.Fatalf("NonTerminal() = %v, want 0 entries once the operation reaches a terminal state", got)
+	}
+}
+
+func TestOperationRegistryNonTerminalOmitsEmptyResourceProvider(t *testing.T) {
+	reg := NewOperationRegistry()
+	reg.Track(&mesos.OperationStatus{
+		OperationId: &mesos.OperationID{Value: strPtr("op1")},
+		State:       mesos.OperationState_OPERATION_PENDING.Enum(),
+	}, "agent1", "")
+
+	got := reg.NonTerminal()
+	if len(got) != 1 {
+		t.Fatalf("NonTerminal() = %v, want 1 entry", got)
+	}
+	if got[0].GetResourceProviderId() != nil {
+		t.Fatalf("ResourceProviderId = %v, want nil for an agent-only operation", got[0].GetResourceProviderId())
+	}
+}
+
+func TestIsTerminalOperation(t *testing.T) {
+	cases := []struct {
+		state mesos.OperationState
+		want  bool
+	}{
+		{mesos.OperationState_OPERATION_PENDING, false},
+		{mesos.OperationState_OPERATION_UNSUPPORTED, false},
+		{mesos.OperationState_OPERATION_FINISHED, true},
+		{mesos.OperationState_OPERATION_FAILED, true},
+		{mesos.OperationState_OPERATION_ERROR, true},
+		{mesos.OperationState_OPERATION_DROPPED, true},
+		{mesos.OperationState_OPERATION_GONE_BY_OPERATOR, true},
+	}
+	for _, c := range cases {
+		if got := isTerminalOperation(c.state); got != c.want {
+			t.Errorf("isTerminalOperation(%v) = %v, want %v", c.state, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Package operations provides helper constructors for building
+// mesos.Offer_Operation values to pass to SchedulerDriver.AcceptOffers.
+package operations
+
+import (
+	mesos "github.com/mesos/go-proto/mesos/v1"
+)
+
+// OpLaunch builds a LAUNCH operation that launches the given tasks using
+// resources from the accepted offers.
+func OpLaunch(tasks []*mesos.TaskInfo) *mesos.Offer_Operation {
+	return &mesos.Offer_Operation{
+		Type: mesos.Offer_Operation_LAUNCH.Enum(),
+		Launch: &mesos.Offer_Operation_Launch{
+			TaskInfos: tasks,
+		},
+	}
+}
+
+// OpLaunchGroup builds a LAUNCH_GROUP operation that launches a task group
+// (e.g., a pod) against the given executor, atomically, using resources
+// from the accepted offers.
+func OpLaunchGroup(executor *mesos.ExecutorInfo, tasks *mesos.TaskGroupInfo) *mesos.Offer_Operation {
+	return &mesos.Offer_Operation{
+		Type: mesos.Offer_Operation_LAUNCH_GROUP.Enum(),
+		LaunchGroup: &mesos.Offer_Operation_LaunchGroup{
+			Executor:  executor,
+			TaskGroup: tasks,
+		},
+	}
+}
+
+// OpReserve builds a RESERVE operation that dynamically reserves the given
+// resources for the framework's role.
+func OpReserve(resources []*mesos.Resource) *mesos.Offer_Operation {
+	return &mesos.Offer_Operation{
+		Type: mesos.Offer_Operation_RESERVE.Enum(),
+		Reserve: &mesos.Offer_Operation_Reserve{
+			Resources: resources,
+		},
+	}
+}
+
+// OpUnreserve builds an UNRESERVE operation that releases previously
+// reserved resources back to the role's unreserved pool.
+func OpUnreserve(resources []*mesos.Resource) *mesos.Offer_Operation {
+	return &mesos.Offer_Operation{
+		Type: mesos.Offer_Operation_UNRESERVE.Enum(),
+		Unreserve: &mesos.Offer_Operation_Unreserve{
+			Resources: resources,
+		},
+	}
+}
+
+// OpCreate builds a CREATE operation that creates the given persistent
+// volumes out of reserved resources.
+func OpCreate(volumes []*mesos.Resource) *mesos.Offer_Operation {
+	return &mesos.Offer_Operation{
+		Type: mesos.Offer_Operation_CREATE.Enum(),
+		Create: &mesos.Offer_Operation_Create{
+			Volumes: volumes,
+		},
+	}
+}
+
+// OpDestroy builds a DESTROY operation that destroys the given persistent
+// volumes, returning their backing resources to the reserved pool.
+func OpDestroy(volumes []*mesos.Resource) *mesos.Offer_Operation {
+	return &mesos.Offer_Operation{
+		Type: mesos.Offer_Operation_DESTROY.Enum(),
+		Destroy: &mesos.Offer_Operation_Destroy{
+			Volumes: volumes,
+		},
+	}
+}
@@ -0,0 +1,83 @@
+package operations
+
+import (
+	"testing"
+
+	mesos "github.com/mesos/go-proto/mesos/v1"
+)
+
+func TestOpLaunch(t *testing.T) {
+	tasks := []*mesos.TaskInfo{{}}
+	op := OpLaunch(tasks)
+
+	if got := op.GetType(); got != mesos.Offer_Operation_LAUNCH {
+		t.Fatalf("Type = %v, want LAUNCH", got)
+	}
+	if got := op.GetLaunch().GetTaskInfos(); len(got) != len(tasks) {
+		t.Fatalf("TaskInfos = %v, want %v", got, tasks)
+	}
+}
+
+func TestOpLaunchGroup(t *testing.T) {
+	executor := &mesos.ExecutorInfo{}
+	group := &mesos.TaskGroupInfo{}
+	op := OpLaunchGroup(executor, group)
+
+	if got := op.GetType(); got != mesos.Offer_Operation_LAUNCH_GROUP {
+		t.Fatalf("Type = %v, want LAUNCH_GROUP", got)
+	}
+	if op.GetLaunchGroup().GetExecutor() != executor {
+		t.Fatalf("Executor not propagated")
+	}
+	if op.GetLaunchGroup().GetTaskGroup() != group {
+		t.Fatalf("TaskGroup not propagated")
+	}
+}
+
+func TestOpReserve(t *testing.T) {
+	resources := []*mesos.Resource{{}}
+	op := OpReserve(resources)
+
+	if got := op.GetType(); got != mesos.Offer_Operation_RESERVE {
+		t.Fatalf("Type = %v, want RESERVE", got)
+	}
+	if got := op.GetReserve().GetResources(); len(got) != len(resources) {
+		t.Fatalf("Resources = %v, want %v", got, resources)
+	}
+}
+
+func TestOpUnreserve(t *testing.T) {
+	resources := []*mesos.Resource{{}}
+	op := OpUnreserve(resources)
+
+	if got := op.GetType(); got != mesos.Offer_Operation_UNRESERVE {
+		t.Fatalf("Type = %v, want UNRESERVE", got)
+	}
+	if got := op.GetUnreserve().GetResources(); len(got) != len(resources) {
+		t.Fatalf("Resources = %v, want %v", got, resources)
+	}
+}
+
+func TestOpCreate(t *testing.T) {
+	volumes := []*mesos.Resource{{}}
+	op := OpCreate(volumes)
+
+	if got := op.GetType(); got != mesos.Offer_Operation_CREATE {
+		t.Fatalf("Type = %v, want CREATE", got)
+	}
+	if got := op.GetCreate().GetVolumes(); len(got) != len(volumes) {
+		t.Fatalf("Volumes = %v, want %v", got, volumes)
+	}
+}
+
+func TestOpDestroy(t *testing.T) {
+	volumes := []*mesos.Resource{{}}
+	op := OpDestroy(volumes)
+
+	if got := op.GetType(); got != mesos.Offer_Operation_DESTROY {
+		t.Fatalf("Type = %v, want DESTROY", got)
+	}
+	if got := op.GetDestroy().GetVolumes(); len(got) != len(volumes) {
+		t.Fatalf("Volumes = %v, want %v", got, volumes)
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	mesos "github.com/mesos/go-proto/mesos/v1"
+)
+
+// ReconcilerPolicy configures the Reconciler subsystem.
+type ReconcilerPolicy struct {
+	// ImplicitInterval is how often implicit reconciliation (an empty
+	// ReconcileTasks call) is issued. Defaults to 15 minutes, per the
+	// Mesos reconciliation guide, if zero.
+	ImplicitInterval time.Duration
+
+	// Deadline is how long a tracked task may go without a refreshed
+	// status before explicit reconciliation is re-issued for it.
+	// Defaults to 1 minute if zero.
+	Deadline time.Duration
+
+	// InitialBackoff and MaxBackoff bound the truncated exponential
+	// backoff applied between successive explicit reconciliation
+	// attempts for the same task. Default to 10s and 10m if zero.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+func (p ReconcilerPolicy) withDefaults() ReconcilerPolicy {
+	if p.ImplicitInterval == 0 {
+		p.ImplicitInterval = 15 * time.Minute
+	}
+	if p.Deadline == 0 {
+		p.Deadline = time.Minute
+	}
+	if p.InitialBackoff == 0 {
+		p.InitialBackoff = 10 * time.Second
+	}
+	if p.MaxBackoff == 0 {
+		p.MaxBackoff = 10 * time.Minute
+	}
+	return p
+}
+
+// Reconciler tracks non-terminal tasks on behalf of a SchedulerDriver and
+// re-issues explicit or implicit reconciliation for them according to a
+// ReconcilerPolicy. It is started via SchedulerDriver.StartReconciler and
+// runs until Stop is called; wiring Stop to Scheduler.Disconnected so the
+// Reconciler halts automatically on disconnect is the concrete driver's
+// responsibility and is not implemented in this tree.
+type Reconciler struct {
+	policy ReconcilerPolicy
+	driver SchedulerDriver
+
+	mu      sync.Mutex
+	tracked map[string]*reconcileState
+	stopCh  chan struct{}
+	stopped bool
+}
+
+type reconcileState struct {
+	status    *mesos.TaskStatus
+	lastSeen  time.Time
+	nextRetry time.Time
+	backoff   time.Duration
+	inFlight  bool
+}
+
+// NewReconciler constructs a Reconciler that will issue reconciliation
+// calls against driver according to policy.
+func NewReconciler(driver SchedulerDriver, policy ReconcilerPolicy) *Reconciler {
+	return &Reconciler{
+		driver:  driver,
+		policy:  policy.withDefaults(),
+		tracked: make(map[string]*reconcileState),
+		stopCh:  make(chan struct{}),
+	}
+}
+
+// Track records status as the last known state of a non-terminal task so
+// the Reconciler can detect a stale status and re-issue explicit
+// reconciliation for it. Terminal statuses are untracked.
+func (r *Reconciler) Track(status *mesos.TaskStatus) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := status.GetTaskId().GetValue()
+	if isTerminal(status.GetState()) {
+		delete(r.tracked, id)
+		return
+	}
+	st, ok := r.tracked[id]
+	if !ok {
+		st = &reconcileState{backoff: r.policy.InitialBackoff}
+		r.tracked[id] = st
+	}
+	st.status = status
+	st.lastSeen = time.Now()
+	st.inFlight = false
+	st.backoff = r.policy.InitialBackoff
+}
+
+// Run drives the Reconciler's periodic implicit reconciliation and
+// deadline-based explicit reconciliation until Stop is called. It is
+// intended to be run in its own goroutine by StartReconciler.
+func (r *Reconciler) Run() {
+	implicitTicker := time.NewTicker(r.policy.ImplicitInterval)
+	defer implicitTicker.Stop()
+
+	// reconcileStale must be driven on a cadence fine enough to notice
+	// a task crossing its Deadline and to retry at its InitialBackoff,
+	// not just once per (much coarser) implicit-reconciliation interval.
+	pollInterval := r.policy.Deadline
+	if r.policy.InitialBackoff < pollInterval {
+		pollInterval = r.policy.InitialBackoff
+	}
+	pollTicker := time.NewTicker(pollInterval)
+	defer pollTicker.Stop()
+
+	for {
+		select {
+		case <-r.stopCh:
+			return
+		case <-implicitTicker.C:
+			r.driver.ReconcileTasks(nil)
+		case <-pollTicker.C:
+			r.reconcileStale()
+		}
+	}
+}
+
+// Stop halts the Reconciler. It is safe to call more than once.
+func (r *Reconciler) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stopped {
+		return
+	}
+	r.stopped = true
+	close(r.stopCh)
+}
+
+func (r *Reconciler) reconcileStale() {
+	now := time.Now()
+
+	r.mu.Lock()
+	var due []*mesos.TaskStatus
+	for _, st := range r.tracked {
+		if st.inFlight {
+			if now.Before(st.nextRetry) {
+				continue
+			}
+			// No refreshed status arrived before the retry deadline;
+			// treat the previous attempt as failed and back off further.
+			st.inFlight = false
+		}
+		if now.Sub(st.lastSeen) < r.policy.Deadline {
+			continue
+		}
+		st.inFlight = true
+		st.nextRetry = now.Add(st.backoff)
+		st.backoff *= 2
+		if st.backoff > r.policy.MaxBackoff {
+			st.backoff = r.policy.MaxBackoff
+		}
+		due = append(due, st.status)
+	}
+	r.mu.Unlock()
+
+	if len(due) > 0 {
+		r.driver.ReconcileTasks(due)
+	}
+}
+
+// isTerminal reports whether state is a terminal task state. Note that
+// TASK_UNREACHABLE is deliberately excluded: an unreachable task can
+// transition back to TASK_RUNNING once a network partition heals, and is
+// precisely the case the Mesos reconciliation guide says frameworks
+// should keep reconciling rather than give up on.
+func isTerminal(state mesos.TaskState) bool {
+	switch state {
+	case mesos.TaskState_TASK_FINISHED,
+		mesos.TaskState_TASK_FAILED,
+		mesos.TaskState_TASK_KILLED,
+		mesos.TaskState_TASK_LOST,
+		mesos.TaskState_TASK_ERROR,
+		mesos.TaskState_TASK_DROPPED,
+		mesos.TaskState_TASK_GONE,
+		mesos.TaskState_TASK_GONE_BY_OPERATOR:
+		return true
+	default:
+		return false
+	}
+}
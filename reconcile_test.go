@@ -0,0 +1,162 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	mesos "github.com/mesos/go-proto/mesos/v1"
+	scheduler "github.com/mesos/go-proto/mesos/v1/scheduler"
+)
+
+func TestIsTerminal(t *testing.T) {
+	cases := []struct {
+		state mesos.TaskState
+		want  bool
+	}{
+		{mesos.TaskState_TASK_RUNNING, false},
+		{mesos.TaskState_TASK_STAGING, false},
+		// TASK_UNREACHABLE can heal back to TASK_RUNNING once a
+		// partition clears, so it must stay non-terminal or the
+		// Reconciler will stop reconciling it.
+		{mesos.TaskState_TASK_UNREACHABLE, false},
+		{mesos.TaskState_TASK_FINISHED, true},
+		{mesos.TaskState_TASK_FAILED, true},
+		{mesos.TaskState_TASK_KILLED, true},
+		{mesos.TaskState_TASK_LOST, true},
+		{mesos.TaskState_TASK_ERROR, true},
+		{mesos.TaskState_TASK_DROPPED, true},
+		{mesos.TaskState_TASK_GONE, true},
+		{mesos.TaskState_TASK_GONE_BY_OPERATOR, true},
+	}
+	for _, c := range cases {
+		if got := isTerminal(c.state); got != c.want {
+			t.Errorf("isTerminal(%v) = %v, want %v", c.state, got, c.want)
+		}
+	}
+}
+
+// recordingDriver is a minimal SchedulerDriver whose only behavior worth
+// observing, for these tests, is which statuses ReconcileTasks was called
+// with.
+type recordingDriver struct {
+	calls [][]*mesos.TaskStatus
+}
+
+func (d *recordingDriver) ReconcileTasks(statuses []*mesos.TaskStatus) error {
+	d.calls = append(d.calls, statuses)
+	return nil
+}
+
+func (d *recordingDriver) Start() error { return nil }
+func (d *recordingDriver) Stop()        {}
+func (d *recordingDriver) LaunchTasks([]*mesos.OfferID, []*mesos.TaskInfo, *mesos.Filters) error {
+	return nil
+}
+func (d *recordingDriver) AcceptOffers([]*mesos.OfferID, []*mesos.Offer_Operation, *mesos.Filters) error {
+	return nil
+}
+func (d *recordingDriver) KillTask(string, string) error                     { return nil }
+func (d *recordingDriver) DeclineOffer(*mesos.OfferID, *mesos.Filters) error { return nil }
+func (d *recordingDriver) ReviveOffers() error                               { return nil }
+func (d *recordingDriver) AcceptInverseOffers([]*mesos.OfferID, *mesos.Filters) error {
+	return nil
+}
+func (d *recordingDriver) DeclineInverseOffer(*mesos.OfferID, *mesos.Filters) error { return nil }
+func (d *recordingDriver) StartReconciler(ReconcilerPolicy) error                   { return nil }
+func (d *recordingDriver) Acknowledge(*mesos.TaskStatus) error                      { return nil }
+func (d *recordingDriver) AcknowledgeOperationStatus(string, string, string, []byte) error {
+	return nil
+}
+func (d *recordingDriver) ReconcileOperations([]*scheduler.Call_ReconcileOperations_Operation) error {
+	return nil
+}
+func (d *recordingDriver) UpdateFramework(*mesos.FrameworkInfo, []string, *OfferConstraints) error {
+	return nil
+}
+
+func newTestReconciler(d SchedulerDriver) *Reconciler {
+	return NewReconciler(d, ReconcilerPolicy{
+		ImplicitInterval: time.Hour,
+		Deadline:         0,
+		InitialBackoff:   time.Minute,
+		MaxBackoff:       4 * time.Minute,
+	})
+}
+
+func TestReconcilerTrackUntracksTerminalTasks(t *testing.T) {
+	r := newTestReconciler(&recordingDriver{})
+	status := &mesos.TaskStatus{
+		TaskId: &mesos.TaskID{Value: strPtr("t1")},
+		State:  mesos.TaskState_TASK_RUNNING.Enum(),
+	}
+	r.Track(status)
+	if _, ok := r.tracked["t1"]; !ok {
+		t.Fatalf("expected t1 to be tracked after a non-terminal status")
+	}
+
+	status.State = mesos.TaskState_TASK_FINISHED.Enum()
+	r.Track(status)
+	if _, ok := r.tracked["t1"]; ok {
+		t.Fatalf("expected t1 to be untracked after a terminal status")
+	}
+}
+
+func TestReconcileStaleBacksOffOnTimeout(t *testing.T) {
+	driver := &recordingDriver{}
+	r := newTestReconciler(driver)
+
+	st := &reconcileState{
+		status:   &mesos.TaskStatus{TaskId: &mesos.TaskID{Value: strPtr("t1")}},
+		lastSeen: time.Now().Add(-time.Hour),
+		backoff:  r.policy.InitialBackoff,
+	}
+	r.tracked["t1"] = st
+
+	r.reconcileStale()
+	if len(driver.calls) != 1 {
+		t.Fatalf("expected 1 ReconcileTasks call after first stale pass, got %d", len(driver.calls))
+	}
+	if !st.inFlight {
+		t.Fatalf("expected task to be marked inFlight after issuing reconciliation")
+	}
+	firstBackoff := st.backoff
+	if firstBackoff != 2*r.policy.InitialBackoff {
+		t.Fatalf("backoff = %v, want %v after first attempt", firstBackoff, 2*r.policy.InitialBackoff)
+	}
+
+	// A second pass before nextRetry elapses must not re-issue.
+	r.reconcileStale()
+	if len(driver.calls) != 1 {
+		t.Fatalf("expected no extra ReconcileTasks call while still within the retry window, got %d", len(driver.calls))
+	}
+
+	// Simulate the retry window elapsing with no response: inFlight
+	// should clear and backoff should double again.
+	st.nextRetry = time.Now().Add(-time.Second)
+	r.reconcileStale()
+	if len(driver.calls) != 2 {
+		t.Fatalf("expected a retry once the window elapsed, got %d calls", len(driver.calls))
+	}
+	if st.backoff != 4*r.policy.InitialBackoff {
+		t.Fatalf("backoff = %v, want %v after second attempt", st.backoff, 4*r.policy.InitialBackoff)
+	}
+}
+
+func TestReconcilerTrackResetsBackoffOnRefresh(t *testing.T) {
+	r := newTestReconciler(&recordingDriver{})
+	r.tracked["t1"] = &reconcileState{
+		status:  &mesos.TaskStatus{TaskId: &mesos.TaskID{Value: strPtr("t1")}},
+		backoff: r.policy.MaxBackoff,
+	}
+
+	r.Track(&mesos.TaskStatus{
+		TaskId: &mesos.TaskID{Value: strPtr("t1")},
+		State:  mesos.TaskState_TASK_RUNNING.Enum(),
+	})
+
+	if got := r.tracked["t1"].backoff; got != r.policy.InitialBackoff {
+		t.Fatalf("backoff = %v, want %v after a refreshed status", got, r.policy.InitialBackoff)
+	}
+}
+
+func strPtr(s string) *string { return &s }
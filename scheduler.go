@@ -2,6 +2,7 @@ package main
 
 import (
 	mesos "github.com/mesos/go-proto/mesos/v1"
+	scheduler "github.com/mesos/go-proto/mesos/v1/scheduler"
 )
 
 // Interface for connecting a scheduler to Mesos. This
@@ -12,7 +13,12 @@ import (
 // impl of a SchedulerDriver.
 type SchedulerDriver interface {
 	// Starts the scheduler driver. This needs to be called before any
-	// other driver calls are made.
+	// other driver calls are made. An implementation constructed with a
+	// Credential (see DriverConfig) is expected to authenticate with the
+	// master via SASL (see CredentialHandler) before subscribing,
+	// retrying with exponential backoff on AuthenticationFailed, and to
+	// hold calls such as LaunchTasks and KillTask until authentication
+	// and subscription have completed.
 	Start() error
 
 	// Stops the scheduler driver.
@@ -28,6 +34,19 @@ type SchedulerDriver interface {
 	// offers in their entirety (see Scheduler::declineOffer).
 	LaunchTasks(offerIDs []*mesos.OfferID, tasks []*mesos.TaskInfo, filters *mesos.Filters) error
 
+	// Accepts the given offers and performs the given sequence of
+	// operations (see the operations package's Op* constructors and
+	// mesos.Offer_Operation) against
+	// them, atomically, as a single Call.Accept to the master. This
+	// supersedes LaunchTasks for frameworks that need to combine
+	// reservation, volume, or task-group operations against the same
+	// offer; LaunchTasks remains available as a convenience wrapper
+	// around a single LAUNCH operation. As with LaunchTasks, any
+	// resources in the named offers that are not consumed by an
+	// operation are declined, and the specified filters are applied to
+	// those unused resources. All offers must belong to the same agent.
+	AcceptOffers(offerIDs []*mesos.OfferID, operations []*mesos.Offer_Operation, filters *mesos.Filters) error
+
 	// Kills the specified task. Note that attempting to kill a task is
 	// currently not reliable. If, for example, a scheduler fails over
 	// while it was attempting to kill a task it will need to retry in
@@ -44,16 +63,50 @@ type SchedulerDriver interface {
 
 	// Removes all filters previously set by the framework (via
 	// LaunchTasks()). This enables the framework to receive offers from
-	// those filtered slaves.
+	// those filtered slaves. Note that this only affects regular
+	// offers; it does not revive inverse offers, which are driven by
+	// the master's maintenance schedule rather than by the framework's
+	// own filters.
 	ReviveOffers() error
 
+	// Accepts the given inverse offers, indicating the framework is
+	// willing to have the corresponding resources removed during the
+	// advertised unavailability window. The specified filters control
+	// how soon the framework may be offered overlapping inverse offers
+	// again. An implementation is expected to track accepted/declined
+	// inverse offers in bookkeeping separate from regular offers, so
+	// that ReviveOffers does not conflate the two; that bookkeeping is
+	// the concrete driver's responsibility and is not implemented in
+	// this interface-only tree.
+	AcceptInverseOffers(offerIDs []*mesos.OfferID, filters *mesos.Filters) error
+
+	// Declines the given inverse offer, indicating the framework is not
+	// (yet) willing to free up the corresponding resources. As with
+	// DeclineOffer, this may be called at any time and the specified
+	// filters are applied to control when the inverse offer may be
+	// resent.
+	DeclineInverseOffer(offerID *mesos.OfferID, filters *mesos.Filters) error
+
 	// Allows the scheduler to query the status for non-terminal tasks.
 	// This causes the master to send back the latest task status for
-	// each task in 'tasks', if possible. Tasks that are no longer known
-	// will result in a TASK_LOST, TASK_UNKNOWN, or TASK_UNREACHABLE update.
-	// If 'tasks' is empty, then the master will send the latest status
-	// for each task currently known.
-	ReconcileTasks() error
+	// each task in 'statuses', if possible. Tasks that are no longer
+	// known will result in a TASK_LOST, TASK_UNKNOWN, or
+	// TASK_UNREACHABLE update. If 'statuses' is empty, this performs
+	// implicit reconciliation: the master sends the latest status for
+	// every task currently known, which is considerably more expensive
+	// for the master than explicit reconciliation and should be used
+	// sparingly. Most frameworks should prefer StartReconciler, which
+	// issues both kinds of reconciliation on a sensible schedule.
+	ReconcileTasks(statuses []*mesos.TaskStatus) error
+
+	// Starts the Reconciler subsystem in the background, governed by
+	// policy. The Reconciler periodically issues implicit reconciliation
+	// and explicit reconciliation for any task whose status has not
+	// been refreshed within policy's deadline, using truncated
+	// exponential backoff. It deduplicates in-flight reconciliation
+	// calls and runs until its Stop method is called; an implementation
+	// is expected to call Stop when the driver disconnects.
+	StartReconciler(policy ReconcilerPolicy) error
 
 	// Acknowledges the receipt of status update. Schedulers are
 	// responsible for explicitly acknowledging the receipt of status
@@ -62,6 +115,37 @@ type SchedulerDriver interface {
 	// the scheduler.
 
 	Acknowledge(status *mesos.TaskStatus) error
+
+	// Acknowledges receipt of an operation status update for the
+	// operation identified by operationID against the agent agentID and
+	// (if the operation targets a resource provider's resources) the
+	// resource provider resourceProviderID. As with Acknowledge, this is
+	// only required for updates that carry a UUID; such updates are
+	// retried by the resource provider until acknowledged. An
+	// implementation is expected to retry the acknowledgment call itself
+	// on transient send failure, the same as it does for Acknowledge;
+	// that retry path, and the event-loop branch that routes
+	// Event.UPDATE_OPERATION_STATUS to OperationStatusUpdate, live in
+	// the concrete driver and are not present in this interface-only
+	// tree.
+	AcknowledgeOperationStatus(agentID, resourceProviderID, operationID string, uuid []byte) error
+
+	// Allows the scheduler to query the status of operations. This
+	// causes the master to send back the latest OperationStatus for
+	// each operation in 'operations', if possible. If 'operations' is
+	// nil, ReconcileOperations performs implicit reconciliation of every
+	// operation currently tracked in the driver's operation registry.
+	ReconcileOperations(operations []*scheduler.Call_ReconcileOperations_Operation) error
+
+	// Updates the master with the framework's current FrameworkInfo,
+	// suppressed roles, and OfferConstraints. This is how a framework
+	// pushes offer constraints after startup (constraints supplied at
+	// driver construction ride the initial SUBSCRIBE call instead).
+	// constraints should satisfy Validate(); an implementation is
+	// expected to compare it against what it last sent via
+	// OfferConstraints.Equal so a no-op update doesn't generate a round
+	// trip to the master.
+	UpdateFramework(frameworkInfo *mesos.FrameworkInfo, suppressedRoles []string, constraints *OfferConstraints) error
 }
 
 // Scheduler a type with callback attributes to be provided by frameworks
@@ -107,6 +191,22 @@ type Scheduler interface {
 	// status updates for those tasks (see Scheduler::resourceOffers).
 	OfferRescinded(SchedulerDriver, *mesos.OfferID)
 
+	// Invoked when inverse offers have been sent to this framework,
+	// typically as a result of the operator scheduling cluster
+	// maintenance. Each inverse offer describes resources the framework
+	// is being asked to give back, along with the Unavailability window
+	// during which those resources will be taken for maintenance.
+	// Frameworks accept (AcceptInverseOffers) or decline
+	// (DeclineInverseOffer) cooperatively; unlike regular offers,
+	// Mesos does not require a response and may proceed with
+	// maintenance regardless.
+	InverseOffersReceived(SchedulerDriver, []*mesos.InverseOffer)
+
+	// Invoked when a previously received inverse offer is no longer
+	// valid (e.g., the maintenance window was cancelled). Identical in
+	// spirit to OfferRescinded, but for inverse offers.
+	InverseOfferRescinded(SchedulerDriver, *mesos.OfferID)
+
 	// Invoked when the status of a task has changed (e.g., a slave is
 	// lost and so the task is lost, a task finishes and an executor
 	// sends a status update saying so, etc). Note that returning from
@@ -116,4 +216,49 @@ type Scheduler interface {
 	// however, that this is currently not true if the slave sending the
 	// status update is lost/fails during that time).
 	StatusUpdate(SchedulerDriver, *mesos.TaskStatus)
+
+	// Invoked when the status of an operation performed against a
+	// resource provider (e.g., a RESERVE or CREATE issued via
+	// AcceptOffers against a CSI resource provider) has changed. Like
+	// StatusUpdate, operation status updates that carry a UUID must be
+	// acknowledged via AcknowledgeOperationStatus; unlike task status,
+	// returning from this callback does not implicitly acknowledge
+	// receipt.
+	OperationStatusUpdate(SchedulerDriver, *mesos.OperationStatus)
+
+	// Invoked when the scheduler re-registers with a newly elected Mesos
+	// master. This is only called when the scheduler has previously been
+	// registered. MasterInfo containing the updated information about
+	// the elected master is provided as an argument.
+	Reregistered(SchedulerDriver, *mesos.MasterInfo)
+
+	// Invoked when the scheduler becomes "disconnected" from the master
+	// (e.g., the master fails over). A framework should not invoke any
+	// SchedulerDriver calls until registered/reregistered again; any
+	// calls made before then will have no effect.
+	Disconnected(SchedulerDriver)
+
+	// Invoked when an agent has been determined unreachable (e.g.,
+	// machine failure, network partition). Most frameworks will need to
+	// reschedule any tasks launched on this agent on a new agent.
+	SlaveLost(SchedulerDriver, *mesos.AgentID)
+
+	// Invoked when an executor has exited/terminated abnormally
+	// (e.g., a runtime error) or its agent is lost. Note that any
+	// tasks running on that executor will have already been reported
+	// as lost via StatusUpdate, so this is purely informational and a
+	// framework need not react to it to make progress.
+	ExecutorLost(SchedulerDriver, *mesos.ExecutorID, *mesos.AgentID, int)
+
+	// Invoked when an executor sends a message. These messages are
+	// best effort; do not expect a framework message to be retransmitted
+	// in any reliable fashion.
+	FrameworkMessage(SchedulerDriver, *mesos.ExecutorID, *mesos.AgentID, []byte)
+
+	// Invoked when there is an unrecoverable error in the scheduler or
+	// scheduler driver (e.g., the framework has been removed, has been
+	// rate-limited permanently, or has failed authentication). The
+	// driver will be aborted before this callback is invoked; any
+	// further calls to the driver will have no effect.
+	Error(SchedulerDriver, string)
 }